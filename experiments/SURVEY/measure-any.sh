@@ -1,8 +1,25 @@
 #!/bin/bash
 # 다국어 측정 스크립트 — Python, TypeScript, JavaScript, Go, Rust, Java, Ruby
+#
+# 사용법: measure-any.sh <repo-url> [--include-generated] [--exclude <glob>]...
+#   기본적으로 vendor/, *.pb.go, "// Code generated ... DO NOT EDIT" 파일은 측정에서 제외한다.
+#   --include-generated: 위 제외를 끈다 (이전 측정 결과와 비교할 때)
+#   --exclude <glob>:    find -path 패턴으로 추가 제외 (반복 가능)
 set -euo pipefail
 
 REPO_URL="$1"
+shift
+
+INCLUDE_GENERATED=0
+USER_EXCLUDES=()
+while [ $# -gt 0 ]; do
+  case "$1" in
+    --include-generated) INCLUDE_GENERATED=1 ;;
+    --exclude) USER_EXCLUDES+=("$2"); shift ;;
+    *) echo "unknown option: $1" >&2; exit 2 ;;
+  esac
+  shift
+done
 REPO_NAME=$(echo "$REPO_URL" | sed 's|.*/||')
 WORK_DIR="/tmp/sc-survey/$REPO_NAME"
 
@@ -13,14 +30,37 @@ git clone --depth 1 "$REPO_URL" "$WORK_DIR" 2>/dev/null
 
 cd "$WORK_DIR"
 
+# 측정 제외 경로 — .git, node_modules는 항상 제외
+EXCLUDES=(-not -path "*/.git/*" -not -path "*/node_modules/*")
+if [ "$INCLUDE_GENERATED" -eq 0 ]; then
+  EXCLUDES+=(-not -path "*/vendor/*" -not -name "*.pb.go")
+fi
+for g in ${USER_EXCLUDES[@]+"${USER_EXCLUDES[@]}"}; do
+  EXCLUDES+=(-not -path "$g")
+done
+
+# 생성 코드 표식 (Go 관례: https://go.dev/s/generatedcode)
+is_generated() {
+  awk 'NR > 20 { exit } /^\/\/ Code generated .* DO NOT EDIT\.$/ { found = 1; exit } END { exit !found }' "$1" 2>/dev/null
+}
+
+# 측정 대상 파일 목록 — src_files <ext> [추가 find 조건...]
+src_files() {
+  local ext="$1"; shift
+  find . -name "*.$ext" "${EXCLUDES[@]}" "$@" | while read -r f; do
+    if [ "$INCLUDE_GENERATED" -eq 0 ] && is_generated "$f"; then continue; fi
+    echo "$f"
+  done
+}
+
 # 언어별 확장자 감지
-PY=$(find . -name "*.py" -not -path "*/.git/*" -not -path "*/node_modules/*" | wc -l | tr -d ' ')
-TS=$(find . -name "*.ts" -not -path "*/.git/*" -not -path "*/node_modules/*" -not -name "*.d.ts" | wc -l | tr -d ' ')
-JS=$(find . -name "*.js" -not -path "*/.git/*" -not -path "*/node_modules/*" -not -name "*.min.js" | wc -l | tr -d ' ')
-GO=$(find . -name "*.go" -not -path "*/.git/*" | wc -l | tr -d ' ')
-RS=$(find . -name "*.rs" -not -path "*/.git/*" | wc -l | tr -d ' ')
-RB=$(find . -name "*.rb" -not -path "*/.git/*" | wc -l | tr -d ' ')
-JV=$(find . -name "*.java" -not -path "*/.git/*" | wc -l | tr -d ' ')
+PY=$(src_files py | wc -l | tr -d ' ')
+TS=$(src_files ts -not -name "*.d.ts" | wc -l | tr -d ' ')
+JS=$(src_files js -not -name "*.min.js" | wc -l | tr -d ' ')
+GO=$(src_files go | wc -l | tr -d ' ')
+RS=$(src_files rs | wc -l | tr -d ' ')
+RB=$(src_files rb | wc -l | tr -d ' ')
+JV=$(src_files java | wc -l | tr -d ' ')
 
 # 가장 많은 언어 선택
 MAX=0; LANG="unknown"; EXT="py"
@@ -36,7 +76,7 @@ echo "total_files: $MAX"
 case "$EXT" in
   py) TEST_FILES=$(find . -name "test_*.py" -o -name "*_test.py" | wc -l | tr -d ' ') ;;
   ts|js) TEST_FILES=$(find . -name "*.test.$EXT" -o -name "*.spec.$EXT" -not -path "*/node_modules/*" | wc -l | tr -d ' ') ;;
-  go) TEST_FILES=$(find . -name "*_test.go" | wc -l | tr -d ' ') ;;
+  go) TEST_FILES=$(src_files go -name "*_test.go" | wc -l | tr -d ' ') ;;
   rs) TEST_FILES=$(grep -rl '#\[cfg(test)\]' --include="*.rs" . 2>/dev/null | wc -l | tr -d ' ') ;;
   rb) TEST_FILES=$(find . -name "*_test.rb" -o -name "*_spec.rb" | wc -l | tr -d ' ') ;;
   java) TEST_FILES=$(find . -name "*Test.java" -o -name "*Tests.java" | wc -l | tr -d ' ') ;;
@@ -54,7 +94,7 @@ case "$EXT" in
   go|rs|java) INDENT=4 ;;  # tab→4
   ts|js|rb) INDENT=2 ;;
 esac
-find . -name "*.$EXT" -not -path "*/.git/*" -not -path "*/node_modules/*" | while read f; do
+src_files "$EXT" | while read f; do
   MAX_D=$(python3 -c "
 import sys
 max_d = 0
@@ -73,26 +113,22 @@ done | sort -rn | head -5
 # Auth 패턴
 echo ""
 echo "--- Auth patterns ---"
-echo "auth refs: $(grep -rl 'auth\|login\|token\|jwt\|oauth\|password\|credential' --include="*.$EXT" . 2>/dev/null | grep -vi test | wc -l | tr -d ' ')"
+echo "auth refs: $(src_files "$EXT" | while read f; do grep -l 'auth\|login\|token\|jwt\|oauth\|password\|credential' "$f"; done 2>/dev/null | grep -vi test | wc -l | tr -d ' ')"
 
 # Secret 패턴
 echo ""
 echo "--- Secret patterns ---"
-echo "secret/key refs: $(grep -r 'SECRET\|API_KEY\|PASSWORD\|CREDENTIAL\|api_key\|secret_key' --include="*.$EXT" . 2>/dev/null | grep -vi test | wc -l | tr -d ' ')"
+echo "secret/key refs: $(src_files "$EXT" | while read f; do grep -H 'SECRET\|API_KEY\|PASSWORD\|CREDENTIAL\|api_key\|secret_key' "$f"; done 2>/dev/null | grep -vi test | wc -l | tr -d ' ')"
 
 # 최대 함수 — 언어별
 echo ""
 echo "--- Largest functions ---"
 case "$EXT" in
   py)
-    python3 -c "
-import ast, os
+    src_files py | python3 -c "
+import ast, sys
 results = []
-for root, dirs, files in os.walk('.'):
-    dirs[:] = [d for d in dirs if d not in ('.git', 'node_modules', '__pycache__')]
-    for fname in files:
-        if not fname.endswith('.py'): continue
-        fpath = os.path.join(root, fname)
+for fpath in sys.stdin.read().splitlines():
         try:
             with open(fpath, errors='ignore') as f: tree = ast.parse(f.read())
             for node in ast.walk(tree):
@@ -107,12 +143,12 @@ for lines, fpath, name in results[:5]:
     ;;
   ts|js)
     # 간이 측정: function/method 사이 줄 수
-    find . -name "*.$EXT" -not -path "*/node_modules/*" -not -path "*/.git/*" | while read f; do
+    src_files "$EXT" | while read f; do
       awk '/^[[:space:]]*(export )?(async )?(function |const \w+ = |class )/{name=$0; start=NR} /^}/{if(start>0){print NR-start+1, FILENAME"::"name; start=0}}' "$f" 2>/dev/null
     done | sort -rn | head -5
     ;;
   go)
-    find . -name "*.go" -not -path "*/.git/*" | while read f; do
+    src_files go | while read f; do
       awk '/^func /{name=$0; start=NR} /^}/{if(start>0){print NR-start+1, FILENAME"::"name; start=0}}' "$f" 2>/dev/null
     done | sort -rn | head -5
     ;;
@@ -125,17 +161,17 @@ esac
 echo ""
 echo "--- SAR candidates ---"
 case "$EXT" in
-  py) grep -rl 'async def' --include="*.py" . 2>/dev/null | while read f; do
+  py) src_files py | xargs -r grep -l 'async def' 2>/dev/null | while read f; do
         HR=$(grep -l 'retry\|Retry\|backoff\|reconnect\|max_retries' "$f" 2>/dev/null | wc -l)
         HS=$(grep -l 'self\.\|_state\|_status\|_cache' "$f" 2>/dev/null | wc -l)
         if [ "$HR" -gt 0 ] && [ "$HS" -gt 0 ]; then echo "SAR: $f"; fi
       done | head -5 ;;
-  ts|js) grep -rl 'async ' --include="*.$EXT" . 2>/dev/null | while read f; do
+  ts|js) src_files "$EXT" | xargs -r grep -l 'async ' 2>/dev/null | while read f; do
            HR=$(grep -l 'retry\|backoff\|reconnect' "$f" 2>/dev/null | wc -l)
            HS=$(grep -l 'this\.\|state\|cache' "$f" 2>/dev/null | wc -l)
            if [ "$HR" -gt 0 ] && [ "$HS" -gt 0 ]; then echo "SAR: $f"; fi
          done | head -5 ;;
-  go) grep -rl 'go func\|goroutine\|chan ' --include="*.go" . 2>/dev/null | while read f; do
+  go) src_files go | xargs -r grep -l 'go func\|goroutine\|chan ' 2>/dev/null | while read f; do
         HR=$(grep -l 'retry\|backoff\|Retry' "$f" 2>/dev/null | wc -l)
         HS=$(grep -l 'mutex\|sync\.\|atomic\.' "$f" 2>/dev/null | wc -l)
         if [ "$HR" -gt 0 ] && [ "$HS" -gt 0 ]; then echo "SAR: $f"; fi